@@ -0,0 +1,151 @@
+// Package api uploads test cases to a QA Sphere project through QA
+// Sphere's HTTP API, as an alternative to generating a CSV with
+// QASphereCSV.WriteCSVToFile and importing it through the UI.
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// defaultBaseURL is QA Sphere's public API endpoint.
+const defaultBaseURL = "https://api.qasphere.com"
+
+// Client uploads test cases to a QA Sphere project.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+	maxRetries int
+	backoff    func(attempt int) time.Duration
+}
+
+// ClientOption configures a Client returned by NewClient.
+type ClientOption func(*Client)
+
+// WithBaseURL overrides QA Sphere's default API base URL, e.g. for a
+// self-hosted instance.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) { c.baseURL = strings.TrimRight(baseURL, "/") }
+}
+
+// WithHTTPClient overrides the http.Client used to make requests.
+func WithHTTPClient(h *http.Client) ClientOption {
+	return func(c *Client) { c.httpClient = h }
+}
+
+// WithMaxRetries overrides how many times a failed request is retried
+// before giving up. Defaults to 3.
+func WithMaxRetries(n int) ClientOption {
+	return func(c *Client) { c.maxRetries = n }
+}
+
+// NewClient returns a Client authenticated with the given API token.
+func NewClient(token string, opts ...ClientOption) *Client {
+	c := &Client{
+		baseURL:    defaultBaseURL,
+		token:      token,
+		httpClient: http.DefaultClient,
+		maxRetries: 3,
+		backoff:    exponentialBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func exponentialBackoff(attempt int) time.Duration {
+	return (1 << attempt) * 100 * time.Millisecond
+}
+
+// requestBody is a fully-rendered HTTP request body plus the Content-
+// Type header it needs, so do/doOnce can retry a request without
+// re-deriving its body representation on every attempt.
+type requestBody struct {
+	contentType string
+	bytes       []byte
+}
+
+// jsonBody renders v as a JSON request body. A nil v yields an empty
+// request body.
+func jsonBody(v any) (requestBody, error) {
+	if v == nil {
+		return requestBody{}, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return requestBody{}, errors.Wrap(err, "marshal request body")
+	}
+	return requestBody{contentType: "application/json", bytes: b}, nil
+}
+
+// do sends an authenticated HTTP request, retrying network errors and
+// 5xx responses with exponential backoff until maxRetries is exhausted
+// or ctx is cancelled.
+func (c *Client) do(ctx context.Context, method, path string, body requestBody) (*http.Response, error) {
+	var lastErr error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(c.backoff(attempt - 1)):
+			}
+		}
+
+		resp, err := c.doOnce(ctx, method, path, body)
+		if err == nil {
+			return resp, nil
+		}
+		if !isRetryable(err) {
+			return nil, err
+		}
+		lastErr = err
+	}
+
+	return nil, errors.Wrapf(lastErr, "%s %s: exhausted retries", method, path)
+}
+
+type retryableError struct{ error }
+
+func isRetryable(err error) bool {
+	_, ok := err.(retryableError)
+	return ok
+}
+
+func (c *Client) doOnce(ctx context.Context, method, path string, body requestBody) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(body.bytes))
+	if err != nil {
+		return nil, errors.Wrap(err, "build request")
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if body.contentType != "" {
+		req.Header.Set("Content-Type", body.contentType)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, retryableError{errors.Wrap(err, "do request")}
+	}
+
+	if resp.StatusCode >= 500 {
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		return nil, retryableError{errors.Errorf("%s %s: status %d: %s", method, path, resp.StatusCode, string(b))}
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		return nil, errors.Errorf("%s %s: status %d: %s", method, path, resp.StatusCode, string(b))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+
+	qascsv "github.com/hypersequent/qasphere-csv"
+)
+
+// UploadOptions configures UploadToProject.
+type UploadOptions struct{}
+
+// UploadToProject uploads every test case in qas to the given QA
+// Sphere project, as an alternative to WriteCSVToFile plus a manual CSV
+// import. qas itself is left untouched: each test case is worked on
+// through its own copy, so uploaded file IDs never leak back into the
+// caller's QASphereCSV. Test cases are re-validated before being sent.
+// Files are uploaded first so the created test cases can reference the
+// resulting file ID: a File with a URL is registered by reference (and
+// a URL is uploaded at most once even if several test cases share it),
+// while a File with neither an ID nor a URL set carries its content in
+// Data and is uploaded directly via multipart.
+func (c *Client) UploadToProject(ctx context.Context, qas *qascsv.QASphereCSV, projectID string, opts UploadOptions) error {
+	validate := validator.New()
+	fileIDByURL := make(map[string]string)
+
+	for _, tc := range qas.TestCases() {
+		if err := validate.Struct(tc); err != nil {
+			return errors.Wrapf(err, "test case %q validation", tc.Title)
+		}
+
+		// TestCases() copies the TestCase struct but not its slice
+		// fields, so tc.Files still aliases qas's own backing array;
+		// clone it before stamping in uploaded file IDs below.
+		tc.Files = append([]qascsv.File(nil), tc.Files...)
+
+		for i, f := range tc.Files {
+			if f.ID != "" {
+				continue
+			}
+
+			if f.URL == "" {
+				id, err := c.uploadFileMultipart(ctx, f)
+				if err != nil {
+					return errors.Wrapf(err, "upload file %q", f.Name)
+				}
+				tc.Files[i].ID = id
+				continue
+			}
+
+			id, ok := fileIDByURL[f.URL]
+			if !ok {
+				var err error
+				id, err = c.uploadFileByURL(ctx, f)
+				if err != nil {
+					return errors.Wrapf(err, "upload file %q", f.Name)
+				}
+				fileIDByURL[f.URL] = id
+			}
+			tc.Files[i].ID = id
+		}
+
+		if err := c.createTestCase(ctx, projectID, tc); err != nil {
+			return errors.Wrapf(err, "create test case %q", tc.Title)
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) uploadFileByURL(ctx context.Context, f qascsv.File) (string, error) {
+	body, err := jsonBody(map[string]any{
+		"file_name": f.Name,
+		"url":       f.URL,
+		"mime_type": f.MimeType,
+		"size":      f.Size,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, "/files", body)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	return decodeFileID(resp.Body)
+}
+
+// uploadFileMultipart uploads f's raw content directly, for a File
+// that has neither an already-uploaded ID nor a retrievable URL.
+func (c *Client) uploadFileMultipart(ctx context.Context, f qascsv.File) (string, error) {
+	body, err := multipartFileBody(f)
+	if err != nil {
+		return "", errors.Wrap(err, "build multipart body")
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, "/files", body)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	return decodeFileID(resp.Body)
+}
+
+func multipartFileBody(f qascsv.File) (requestBody, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	for field, value := range map[string]string{
+		"file_name": f.Name,
+		"mime_type": f.MimeType,
+		"size":      strconv.FormatInt(f.Size, 10),
+	} {
+		if err := mw.WriteField(field, value); err != nil {
+			return requestBody{}, err
+		}
+	}
+
+	part, err := mw.CreateFormFile("file", f.Name)
+	if err != nil {
+		return requestBody{}, err
+	}
+	if _, err := part.Write(f.Data); err != nil {
+		return requestBody{}, err
+	}
+	if err := mw.Close(); err != nil {
+		return requestBody{}, err
+	}
+
+	return requestBody{contentType: mw.FormDataContentType(), bytes: buf.Bytes()}, nil
+}
+
+func decodeFileID(r io.Reader) (string, error) {
+	var out struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(r).Decode(&out); err != nil {
+		return "", errors.Wrap(err, "decode file upload response")
+	}
+	return out.ID, nil
+}
+
+func (c *Client) createTestCase(ctx context.Context, projectID string, tc qascsv.TestCase) error {
+	path := fmt.Sprintf("/projects/%s/test-cases", url.PathEscape(projectID))
+	body, err := jsonBody(map[string]any{
+		"folder":        tc.Folder,
+		"title":         tc.Title,
+		"legacy_id":     tc.LegacyID,
+		"priority":      tc.Priority,
+		"tags":          tc.Tags,
+		"preconditions": tc.Preconditions,
+		"steps":         tc.Steps,
+		"requirement":   tc.Requirement,
+		"files":         tc.Files,
+		"links":         tc.Links,
+		"draft":         tc.Draft,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
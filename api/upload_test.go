@@ -0,0 +1,192 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	qascsv "github.com/hypersequent/qasphere-csv"
+)
+
+// newFakeServer returns an httptest server that accepts file uploads
+// and test case creation, recording each request it handles.
+func newFakeServer(t *testing.T) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	var created []string
+	fileCount := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/files", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		fileCount++
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "file-id"})
+	})
+	mux.HandleFunc("/projects/proj-1/test-cases", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		created = append(created, body["title"].(string))
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, &created
+}
+
+func TestUploadToProject(t *testing.T) {
+	srv, created := newFakeServer(t)
+
+	qas := qascsv.NewQASphereCSV()
+	require.NoError(t, qas.AddTestCases([]qascsv.TestCase{
+		{
+			Title:    "tc-with-file",
+			Folder:   []string{"root"},
+			Priority: qascsv.PriorityHigh,
+			Files:    []qascsv.File{{Name: "a.png", URL: "http://files/a.png", MimeType: "image/png", Size: 10}},
+		},
+		{
+			Title:    "tc-without-file",
+			Folder:   []string{"root"},
+			Priority: qascsv.PriorityLow,
+		},
+	}))
+
+	client := NewClient("test-token", WithBaseURL(srv.URL))
+	err := client.UploadToProject(context.Background(), qas, "proj-1", UploadOptions{})
+	require.NoError(t, err)
+
+	require.ElementsMatch(t, []string{"tc-with-file", "tc-without-file"}, *created)
+}
+
+func TestUploadToProjectMultipart(t *testing.T) {
+	var contentType string
+	var fileField []byte
+	var formFields map[string]string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/files", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+
+		contentType = r.Header.Get("Content-Type")
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		formFields = map[string]string{
+			"file_name": r.FormValue("file_name"),
+			"mime_type": r.FormValue("mime_type"),
+			"size":      r.FormValue("size"),
+		}
+
+		f, _, err := r.FormFile("file")
+		require.NoError(t, err)
+		defer f.Close()
+		fileField, err = io.ReadAll(f)
+		require.NoError(t, err)
+
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "uploaded-file-id"})
+	})
+	mux.HandleFunc("/projects/proj-1/test-cases", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		files, _ := body["files"].([]any)
+		require.Len(t, files, 1)
+		file, _ := files[0].(map[string]any)
+		require.Equal(t, "uploaded-file-id", file["id"])
+		w.WriteHeader(http.StatusCreated)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	qas := qascsv.NewQASphereCSV()
+	require.NoError(t, qas.AddTestCase(qascsv.TestCase{
+		Title:    "tc-with-local-file",
+		Folder:   []string{"root"},
+		Priority: qascsv.PriorityHigh,
+		Files:    []qascsv.File{{Name: "a.png", MimeType: "image/png", Size: 3, Data: []byte("abc")}},
+	}))
+
+	client := NewClient("test-token", WithBaseURL(srv.URL))
+	err := client.UploadToProject(context.Background(), qas, "proj-1", UploadOptions{})
+	require.NoError(t, err)
+
+	require.Contains(t, contentType, "multipart/form-data")
+	require.Equal(t, []byte("abc"), fileField)
+	require.Equal(t, "a.png", formFields["file_name"])
+	require.Equal(t, "image/png", formFields["mime_type"])
+	require.Equal(t, "3", formFields["size"])
+}
+
+func TestUploadToProjectRetriesOn5xx(t *testing.T) {
+	var attempts int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/projects/proj-1/test-cases", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	qas := qascsv.NewQASphereCSV()
+	require.NoError(t, qas.AddTestCase(qascsv.TestCase{Title: "tc", Folder: []string{"root"}, Priority: qascsv.PriorityHigh}))
+
+	client := NewClient("test-token", WithBaseURL(srv.URL), WithMaxRetries(3))
+	// Make the test fast: the production backoff doubles from 100ms per retry.
+	client.backoff = func(attempt int) time.Duration { return time.Millisecond }
+
+	err := client.UploadToProject(context.Background(), qas, "proj-1", UploadOptions{})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, atomic.LoadInt32(&attempts))
+}
+
+func TestUploadToProjectFailsAfterMaxRetries(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/projects/proj-1/test-cases", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	qas := qascsv.NewQASphereCSV()
+	require.NoError(t, qas.AddTestCase(qascsv.TestCase{Title: "tc", Folder: []string{"root"}, Priority: qascsv.PriorityHigh}))
+
+	client := NewClient("test-token", WithBaseURL(srv.URL), WithMaxRetries(1))
+	client.backoff = func(attempt int) time.Duration { return time.Millisecond }
+
+	err := client.UploadToProject(context.Background(), qas, "proj-1", UploadOptions{})
+	require.Error(t, err)
+}
+
+func TestUploadToProjectCancelledContext(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/projects/proj-1/test-cases", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	qas := qascsv.NewQASphereCSV()
+	require.NoError(t, qas.AddTestCase(qascsv.TestCase{Title: "tc", Folder: []string{"root"}, Priority: qascsv.PriorityHigh}))
+
+	client := NewClient("test-token", WithBaseURL(srv.URL), WithMaxRetries(5))
+	client.backoff = func(attempt int) time.Duration { return time.Hour }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := client.UploadToProject(ctx, qas, "proj-1", UploadOptions{})
+	require.Error(t, err)
+}
@@ -0,0 +1,66 @@
+// Command qasphere-import converts a test case export from another
+// test-management tool into a QA Sphere CSV, so it can be imported into
+// a QA Sphere project.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	qascsv "github.com/hypersequent/qasphere-csv"
+	"github.com/hypersequent/qasphere-csv/importers"
+)
+
+func main() {
+	format := flag.String("format", "", "source format: testrail, xray, json")
+	in := flag.String("in", "", "path to the source export file")
+	out := flag.String("out", "", "path to write the QA Sphere CSV to")
+	flag.Parse()
+
+	if *format == "" || *in == "" || *out == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	importer, err := importerFor(*format)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	f, err := os.Open(*in)
+	if err != nil {
+		log.Fatalf("open input: %v", err)
+	}
+	defer f.Close()
+
+	tcs, err := importer.Import(f)
+	if err != nil {
+		log.Fatalf("import %s: %v", *format, err)
+	}
+
+	qasCSV := qascsv.NewQASphereCSV()
+	if err := qasCSV.AddTestCases(tcs); err != nil {
+		log.Fatalf("add test cases: %v", err)
+	}
+
+	if err := qasCSV.WriteCSVToFile(*out); err != nil {
+		log.Fatalf("write csv: %v", err)
+	}
+
+	fmt.Printf("imported %d test case(s) into %s\n", len(tcs), *out)
+}
+
+func importerFor(format string) (importers.Importer, error) {
+	switch format {
+	case "testrail":
+		return importers.NewTestRailImporter(), nil
+	case "xray":
+		return importers.NewXrayImporter(), nil
+	case "json":
+		return importers.NewJSONImporter(), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q (want testrail, xray, or json)", format)
+	}
+}
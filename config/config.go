@@ -0,0 +1,73 @@
+// Package config loads a declarative test suite description - in YAML,
+// JSON or TOML - into a *qascsv.QASphereCSV, so a whole suite of test
+// cases can be kept as versioned config files instead of being built up
+// in Go. Common Preconditions, Tags or Steps can be defined once in the
+// document and referenced by name from many test cases, and a test
+// case can carry a Template to expand it into one case per entry of a
+// parameter list (e.g. one case per browser).
+package config
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	qascsv "github.com/hypersequent/qasphere-csv"
+)
+
+// LoadFile loads the test suite config at path - format is inferred
+// from its extension (.yaml/.yml, .json or .toml) - and returns it as a
+// *qascsv.QASphereCSV with every test case already added.
+func LoadFile(path string) (*qascsv.QASphereCSV, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "open config")
+	}
+	defer f.Close()
+
+	doc, err := decodeDocument(filepath.Ext(path), f)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode config")
+	}
+
+	tcs, err := doc.Expand()
+	if err != nil {
+		return nil, errors.Wrap(err, "expand config")
+	}
+
+	qas := qascsv.NewQASphereCSV()
+	if err := qas.AddTestCases(tcs); err != nil {
+		return nil, errors.Wrap(err, "add test cases")
+	}
+
+	return qas, nil
+}
+
+func decodeDocument(ext string, r io.Reader) (*Document, error) {
+	var doc Document
+
+	switch strings.ToLower(ext) {
+	case ".yaml", ".yml":
+		if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+			return nil, errors.Wrap(err, "decode yaml")
+		}
+	case ".json":
+		if err := json.NewDecoder(r).Decode(&doc); err != nil {
+			return nil, errors.Wrap(err, "decode json")
+		}
+	case ".toml":
+		if _, err := toml.NewDecoder(r).Decode(&doc); err != nil {
+			return nil, errors.Wrap(err, "decode toml")
+		}
+	default:
+		return nil, errors.Errorf("unsupported config format %q", ext)
+	}
+
+	return &doc, nil
+}
@@ -0,0 +1,145 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const yamlDoc = `
+preconditions:
+  logged_in: User is logged in
+
+tags:
+  smoke:
+    - smoke
+    - regression
+
+steps:
+  open_app:
+    - action: Open the app
+
+test_cases:
+  - title: Checkout works
+    folder: [Shop, Checkout]
+    priority: high
+    use_preconditions: logged_in
+    use_tags: [smoke]
+    use_steps: [open_app]
+    steps:
+      - action: Click checkout
+        expected: Order is placed
+
+  - title: "Works in {{.browser}}"
+    folder: [Shop, UI]
+    priority: low
+    preconditions: "Browser is {{.browser}}"
+    steps:
+      - action: Open the home page
+        expected: Page renders in {{.browser}}
+    template:
+      vars:
+        - browser: Chrome
+        - browser: Firefox
+`
+
+func TestLoadFileYAML(t *testing.T) {
+	path := writeTemp(t, "suite.yaml", yamlDoc)
+
+	qas, err := LoadFile(path)
+	require.NoError(t, err)
+
+	tcs := qas.TestCases()
+	require.Len(t, tcs, 3)
+
+	checkout := tcs[0]
+	require.Equal(t, "Checkout works", checkout.Title)
+	require.Equal(t, []string{"Shop", "Checkout"}, checkout.Folder)
+	require.Equal(t, "User is logged in", checkout.Preconditions)
+	require.Equal(t, []string{"smoke", "regression"}, checkout.Tags)
+	require.Len(t, checkout.Steps, 2)
+	require.Equal(t, "Open the app", checkout.Steps[0].Action)
+	require.Equal(t, "Click checkout", checkout.Steps[1].Action)
+
+	var titles []string
+	for _, tc := range tcs[1:] {
+		titles = append(titles, tc.Title)
+	}
+	require.ElementsMatch(t, []string{"Works in Chrome", "Works in Firefox"}, titles)
+	require.Equal(t, "Browser is Chrome", tcs[1].Preconditions)
+	require.Equal(t, "Page renders in Chrome", tcs[1].Steps[0].Expected)
+}
+
+const jsonDoc = `{
+  "test_cases": [
+    {
+      "title": "Login works",
+      "folder": ["Shop", "Auth"],
+      "priority": "medium",
+      "steps": [{"action": "Log in", "expected": "User is on dashboard"}]
+    }
+  ]
+}`
+
+func TestLoadFileJSON(t *testing.T) {
+	path := writeTemp(t, "suite.json", jsonDoc)
+
+	qas, err := LoadFile(path)
+	require.NoError(t, err)
+
+	tcs := qas.TestCases()
+	require.Len(t, tcs, 1)
+	require.Equal(t, "Login works", tcs[0].Title)
+}
+
+const tomlDoc = `
+[[test_cases]]
+title = "Logout works"
+folder = ["Shop", "Auth"]
+priority = "low"
+
+[[test_cases.steps]]
+action = "Log out"
+expected = "User is on the login page"
+`
+
+func TestLoadFileTOML(t *testing.T) {
+	path := writeTemp(t, "suite.toml", tomlDoc)
+
+	qas, err := LoadFile(path)
+	require.NoError(t, err)
+
+	tcs := qas.TestCases()
+	require.Len(t, tcs, 1)
+	require.Equal(t, "Logout works", tcs[0].Title)
+	require.Equal(t, "Log out", tcs[0].Steps[0].Action)
+}
+
+func TestLoadFileUnknownFragment(t *testing.T) {
+	path := writeTemp(t, "suite.yaml", `
+test_cases:
+  - title: Broken
+    folder: [root]
+    priority: high
+    use_preconditions: does-not-exist
+`)
+
+	_, err := LoadFile(path)
+	require.Error(t, err)
+}
+
+func TestLoadFileUnsupportedFormat(t *testing.T) {
+	path := writeTemp(t, "suite.txt", "irrelevant")
+
+	_, err := LoadFile(path)
+	require.Error(t, err)
+}
+
+func writeTemp(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
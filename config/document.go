@@ -0,0 +1,81 @@
+package config
+
+// Document is the declarative shape of a test suite config file,
+// decoded from YAML, JSON or TOML by LoadFile. It describes a whole
+// suite of test cases, plus shared fragments that individual test
+// cases can reference by name instead of repeating themselves.
+type Document struct {
+	// Preconditions holds named, reusable Preconditions text, keyed by
+	// name and referenced from a CaseDoc via UsePreconditions.
+	Preconditions map[string]string `yaml:"preconditions" json:"preconditions" toml:"preconditions"`
+	// Tags holds named, reusable tag sets, keyed by name and merged
+	// into a CaseDoc's own Tags via UseTags.
+	Tags map[string][]string `yaml:"tags" json:"tags" toml:"tags"`
+	// Steps holds named, reusable step sequences, keyed by name and
+	// referenced from a CaseDoc via UseSteps.
+	Steps map[string][]StepDoc `yaml:"steps" json:"steps" toml:"steps"`
+
+	TestCases []CaseDoc `yaml:"test_cases" json:"test_cases" toml:"test_cases"`
+}
+
+// StepDoc is a single test step. Action and Expected are expanded as Go
+// templates when the owning CaseDoc has a Template.
+type StepDoc struct {
+	Action   string `yaml:"action" json:"action" toml:"action"`
+	Expected string `yaml:"expected" json:"expected" toml:"expected"`
+}
+
+// RequirementDoc mirrors qascsv.Requirement.
+type RequirementDoc struct {
+	Title string `yaml:"title" json:"title" toml:"title"`
+	URL   string `yaml:"url" json:"url" toml:"url"`
+}
+
+// LinkDoc mirrors qascsv.Link.
+type LinkDoc struct {
+	Title string `yaml:"title" json:"title" toml:"title"`
+	URL   string `yaml:"url" json:"url" toml:"url"`
+}
+
+// FileDoc mirrors qascsv.File.
+type FileDoc struct {
+	Name     string `yaml:"name" json:"name" toml:"name"`
+	ID       string `yaml:"id" json:"id" toml:"id"`
+	URL      string `yaml:"url" json:"url" toml:"url"`
+	MimeType string `yaml:"mime_type" json:"mime_type" toml:"mime_type"`
+	Size     int64  `yaml:"size" json:"size" toml:"size"`
+}
+
+// TemplateDoc expands its owning CaseDoc once per entry in Vars,
+// rendering the case's Title, Preconditions and each step's
+// Action/Expected as a Go text/template with that entry as the template
+// data; other fields are copied unrendered. This is how one CaseDoc
+// becomes e.g. one test case per browser.
+type TemplateDoc struct {
+	Vars []map[string]string `yaml:"vars" json:"vars" toml:"vars"`
+}
+
+// CaseDoc is a single test case, or a template for a family of test
+// cases when Template is set.
+type CaseDoc struct {
+	Title    string   `yaml:"title" json:"title" toml:"title"`
+	LegacyID string   `yaml:"legacy_id" json:"legacy_id" toml:"legacy_id"`
+	Folder   []string `yaml:"folder" json:"folder" toml:"folder"`
+	Priority string   `yaml:"priority" json:"priority" toml:"priority"`
+	Draft    bool     `yaml:"draft" json:"draft" toml:"draft"`
+
+	Tags    []string `yaml:"tags" json:"tags" toml:"tags"`
+	UseTags []string `yaml:"use_tags" json:"use_tags" toml:"use_tags"`
+
+	Preconditions    string `yaml:"preconditions" json:"preconditions" toml:"preconditions"`
+	UsePreconditions string `yaml:"use_preconditions" json:"use_preconditions" toml:"use_preconditions"`
+
+	Steps    []StepDoc `yaml:"steps" json:"steps" toml:"steps"`
+	UseSteps []string  `yaml:"use_steps" json:"use_steps" toml:"use_steps"`
+
+	Requirement *RequirementDoc `yaml:"requirement" json:"requirement" toml:"requirement"`
+	Links       []LinkDoc       `yaml:"links" json:"links" toml:"links"`
+	Files       []FileDoc       `yaml:"files" json:"files" toml:"files"`
+
+	Template *TemplateDoc `yaml:"template" json:"template" toml:"template"`
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	qascsv "github.com/hypersequent/qasphere-csv"
+)
+
+// Expand resolves every test case's fragment references and template
+// parameterization into plain qascsv.TestCase values.
+func (doc *Document) Expand() ([]qascsv.TestCase, error) {
+	var tcs []qascsv.TestCase
+
+	for i, c := range doc.TestCases {
+		resolved, err := doc.resolve(c)
+		if err != nil {
+			return nil, errors.Wrapf(err, "test case %d", i)
+		}
+
+		if resolved.Template == nil {
+			tcs = append(tcs, toTestCase(resolved))
+			continue
+		}
+
+		for j, vars := range resolved.Template.Vars {
+			rendered, err := renderCase(resolved, vars)
+			if err != nil {
+				return nil, errors.Wrapf(err, "test case %d template %d", i, j)
+			}
+			tcs = append(tcs, toTestCase(rendered))
+		}
+	}
+
+	return tcs, nil
+}
+
+// resolve merges a CaseDoc's own fields with the named fragments it
+// references via UsePreconditions/UseTags/UseSteps. An explicit
+// Preconditions on the case itself wins over the fragment; tags and
+// steps referenced by name are prepended to the case's own.
+func (doc *Document) resolve(c CaseDoc) (CaseDoc, error) {
+	if c.UsePreconditions != "" {
+		pre, ok := doc.Preconditions[c.UsePreconditions]
+		if !ok {
+			return CaseDoc{}, errors.Errorf("unknown preconditions fragment %q", c.UsePreconditions)
+		}
+		if c.Preconditions == "" {
+			c.Preconditions = pre
+		}
+	}
+
+	for _, name := range c.UseTags {
+		tags, ok := doc.Tags[name]
+		if !ok {
+			return CaseDoc{}, errors.Errorf("unknown tags fragment %q", name)
+		}
+		c.Tags = append(append([]string{}, tags...), c.Tags...)
+	}
+
+	for _, name := range c.UseSteps {
+		steps, ok := doc.Steps[name]
+		if !ok {
+			return CaseDoc{}, errors.Errorf("unknown steps fragment %q", name)
+		}
+		c.Steps = append(append([]StepDoc{}, steps...), c.Steps...)
+	}
+
+	return c, nil
+}
+
+// renderCase renders every templated string field of c (Title,
+// Preconditions, and each step's Action/Expected) as a Go text/template
+// with vars as the template data.
+func renderCase(c CaseDoc, vars map[string]string) (CaseDoc, error) {
+	var err error
+	render := func(s string) string {
+		if err != nil || !strings.Contains(s, "{{") {
+			return s
+		}
+		var rendered string
+		rendered, err = renderTemplate(s, vars)
+		return rendered
+	}
+
+	c.Title = render(c.Title)
+	c.Preconditions = render(c.Preconditions)
+
+	steps := make([]StepDoc, len(c.Steps))
+	for i, s := range c.Steps {
+		steps[i] = StepDoc{Action: render(s.Action), Expected: render(s.Expected)}
+	}
+	c.Steps = steps
+
+	return c, err
+}
+
+func renderTemplate(text string, vars map[string]string) (string, error) {
+	tmpl, err := template.New("case").Parse(text)
+	if err != nil {
+		return "", errors.Wrap(err, "parse template")
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", errors.Wrap(err, "execute template")
+	}
+	return buf.String(), nil
+}
+
+func toTestCase(c CaseDoc) qascsv.TestCase {
+	tc := qascsv.TestCase{
+		Title:         c.Title,
+		LegacyID:      c.LegacyID,
+		Folder:        c.Folder,
+		Priority:      qascsv.Priority(c.Priority),
+		Tags:          c.Tags,
+		Preconditions: c.Preconditions,
+		Draft:         c.Draft,
+	}
+
+	for _, s := range c.Steps {
+		tc.Steps = append(tc.Steps, qascsv.Step{Action: s.Action, Expected: s.Expected})
+	}
+	if c.Requirement != nil {
+		tc.Requirement = &qascsv.Requirement{Title: c.Requirement.Title, URL: c.Requirement.URL}
+	}
+	for _, l := range c.Links {
+		tc.Links = append(tc.Links, qascsv.Link{Title: l.Title, URL: l.URL})
+	}
+	for _, f := range c.Files {
+		tc.Files = append(tc.Files, qascsv.File{Name: f.Name, ID: f.ID, URL: f.URL, MimeType: f.MimeType, Size: f.Size})
+	}
+
+	return tc
+}
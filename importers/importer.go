@@ -0,0 +1,19 @@
+// Package importers provides adapters that convert test case exports
+// from other test-management tools into QA Sphere's TestCase model, so
+// they can be fed into qascsv.QASphereCSV and exported as a QA Sphere
+// CSV.
+package importers
+
+import (
+	"io"
+
+	qascsv "github.com/hypersequent/qasphere-csv"
+)
+
+// Importer converts a test case export from another tool into QA
+// Sphere TestCase structs. Implementations are expected to validate the
+// test cases they produce (e.g. by running them through a
+// *qascsv.QASphereCSV) before returning them.
+type Importer interface {
+	Import(r io.Reader) ([]qascsv.TestCase, error)
+}
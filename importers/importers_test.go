@@ -0,0 +1,72 @@
+package importers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestRailImporter(t *testing.T) {
+	csv := "Title,Section,Priority,References,Preconditions,Steps,Expected Result\n" +
+		"\"Login works\",\"App > Auth\",\"3 - High\",\"REQ-1,REQ-2\",\"User is logged out\"," +
+		"\"Open login page\nEnter credentials\",\"Login form is shown\nUser is logged in\"\n"
+
+	tcs, err := NewTestRailImporter().Import(strings.NewReader(csv))
+	require.NoError(t, err)
+	require.Len(t, tcs, 1)
+
+	tc := tcs[0]
+	require.Equal(t, "Login works", tc.Title)
+	require.Equal(t, []string{"App", "Auth"}, tc.Folder)
+	require.EqualValues(t, "high", tc.Priority)
+	require.Equal(t, "User is logged out", tc.Preconditions)
+	require.Equal(t, []string{"REQ-2"}, tc.Tags)
+	require.NotNil(t, tc.Requirement)
+	require.Equal(t, "REQ-1", tc.Requirement.Title)
+	require.Len(t, tc.Steps, 2)
+	require.Equal(t, "Open login page", tc.Steps[0].Action)
+	require.Equal(t, "User is logged in", tc.Steps[1].Expected)
+}
+
+func TestXrayImporter(t *testing.T) {
+	csv := "Key,Summary,Priority,Labels,Folder,Precondition,Requirement,Action,Expected Result,Attachments\n" +
+		"TC-1,Login works,High,smoke,App/Auth,User is logged out,REQ-1,Open login page,Login form is shown,\n" +
+		"TC-1,Login works,High,smoke,App/Auth,User is logged out,REQ-1,Enter credentials,User is logged in,http://files/shot.png\n"
+
+	tcs, err := NewXrayImporter().Import(strings.NewReader(csv))
+	require.NoError(t, err)
+	require.Len(t, tcs, 1)
+
+	tc := tcs[0]
+	require.Equal(t, "Login works", tc.Title)
+	require.Equal(t, []string{"App", "Auth"}, tc.Folder)
+	require.EqualValues(t, "high", tc.Priority)
+	require.Equal(t, []string{"smoke"}, tc.Tags)
+	require.Len(t, tc.Steps, 2)
+	require.Len(t, tc.Files, 1)
+	require.Equal(t, "shot.png", tc.Files[0].Name)
+}
+
+func TestJSONImporter(t *testing.T) {
+	src := `[{
+		"title": "Login works",
+		"folder": ["App", "Auth"],
+		"priority": "high",
+		"steps": [{"action": "Open login page", "expected": "Login form is shown"}]
+	}]`
+
+	tcs, err := NewJSONImporter().Import(strings.NewReader(src))
+	require.NoError(t, err)
+	require.Len(t, tcs, 1)
+	require.Equal(t, "Login works", tcs[0].Title)
+	require.Len(t, tcs[0].Steps, 1)
+}
+
+func TestImportersValidationError(t *testing.T) {
+	csv := "Title,Section,Priority,References,Preconditions,Steps,Expected Result\n" +
+		",App,High,,,,\n"
+
+	_, err := NewTestRailImporter().Import(strings.NewReader(csv))
+	require.Error(t, err)
+}
@@ -0,0 +1,76 @@
+package importers
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	qascsv "github.com/hypersequent/qasphere-csv"
+)
+
+// jsonTestCase mirrors qascsv.TestCase field-for-field, using plain
+// JSON names so any tool that can emit a flat JSON array of test cases
+// can be imported without a custom format.
+type jsonTestCase struct {
+	Title         string              `json:"title"`
+	LegacyID      string              `json:"legacy_id"`
+	Folder        []string            `json:"folder"`
+	Priority      qascsv.Priority     `json:"priority"`
+	Tags          []string            `json:"tags"`
+	Preconditions string              `json:"preconditions"`
+	Steps         []qascsv.Step       `json:"steps"`
+	Requirement   *qascsv.Requirement `json:"requirement"`
+	Files         []qascsv.File       `json:"files"`
+	Links         []qascsv.Link       `json:"links"`
+	Draft         bool                `json:"draft"`
+}
+
+// JSONImporter imports a flat JSON array of test cases whose fields map
+// directly onto qascsv.TestCase (see jsonTestCase), e.g.:
+//
+//	[{"title": "...", "folder": ["root"], "priority": "high", "steps": [...]}]
+type JSONImporter struct{}
+
+// NewJSONImporter returns a JSONImporter.
+func NewJSONImporter() *JSONImporter {
+	return &JSONImporter{}
+}
+
+func (i *JSONImporter) Import(r io.Reader) ([]qascsv.TestCase, error) {
+	var jtcs []jsonTestCase
+	if err := json.NewDecoder(r).Decode(&jtcs); err != nil {
+		return nil, errors.Wrap(err, "decode json")
+	}
+
+	validate := validator.New()
+	var tcs []qascsv.TestCase
+	var verr error
+	for i, jtc := range jtcs {
+		tc := qascsv.TestCase{
+			Title:         jtc.Title,
+			LegacyID:      jtc.LegacyID,
+			Folder:        jtc.Folder,
+			Priority:      jtc.Priority,
+			Tags:          jtc.Tags,
+			Preconditions: jtc.Preconditions,
+			Steps:         jtc.Steps,
+			Requirement:   jtc.Requirement,
+			Files:         jtc.Files,
+			Links:         jtc.Links,
+			Draft:         jtc.Draft,
+		}
+		if err := validate.Struct(tc); err != nil {
+			verr = multierror.Append(verr, errors.Wrapf(err, "test case %d", i))
+			continue
+		}
+		tcs = append(tcs, tc)
+	}
+	if verr != nil {
+		return nil, errors.Wrap(verr, "validation")
+	}
+
+	return tcs, nil
+}
@@ -0,0 +1,149 @@
+package importers
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	qascsv "github.com/hypersequent/qasphere-csv"
+)
+
+// testRailPriority maps TestRail's default priority names to QA Sphere
+// priorities. Unrecognised values fall back to medium.
+var testRailPriority = map[string]qascsv.Priority{
+	"1 - low":      qascsv.PriorityLow,
+	"low":          qascsv.PriorityLow,
+	"2 - medium":   qascsv.PriorityMedium,
+	"medium":       qascsv.PriorityMedium,
+	"3 - high":     qascsv.PriorityHigh,
+	"high":         qascsv.PriorityHigh,
+	"4 - critical": qascsv.PriorityHigh,
+	"critical":     qascsv.PriorityHigh,
+}
+
+// TestRailImporter imports TestRail's "Test Cases" CSV export, as
+// produced by TestRail's CSV exporter with the default field mapping:
+// Title, Section, Priority, References, Preconditions, Steps, Expected
+// Result. Section is a " > " separated suite/section path, and Steps
+// and Expected Result hold one step per line, newline separated.
+type TestRailImporter struct{}
+
+// NewTestRailImporter returns a TestRailImporter.
+func NewTestRailImporter() *TestRailImporter {
+	return &TestRailImporter{}
+}
+
+func (i *TestRailImporter) Import(r io.Reader) ([]qascsv.TestCase, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "read csv")
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("empty csv")
+	}
+
+	col := make(map[string]int, len(rows[0]))
+	for idx, name := range rows[0] {
+		col[name] = idx
+	}
+
+	validate := validator.New()
+	var tcs []qascsv.TestCase
+	var verr error
+	for i, row := range rows[1:] {
+		tc := testRailRowToTestCase(row, col)
+		if err := validate.Struct(tc); err != nil {
+			verr = multierror.Append(verr, errors.Wrapf(err, "row %d", i))
+			continue
+		}
+		tcs = append(tcs, tc)
+	}
+	if verr != nil {
+		return nil, errors.Wrap(verr, "validation")
+	}
+
+	return tcs, nil
+}
+
+func testRailRowToTestCase(row []string, col map[string]int) qascsv.TestCase {
+	get := func(name string) string {
+		idx, ok := col[name]
+		if !ok || idx >= len(row) {
+			return ""
+		}
+		return row[idx]
+	}
+
+	priority, ok := testRailPriority[strings.ToLower(strings.TrimSpace(get("Priority")))]
+	if !ok {
+		priority = qascsv.PriorityMedium
+	}
+
+	tc := qascsv.TestCase{
+		Title:         get("Title"),
+		Folder:        splitNonEmpty(get("Section"), " > "),
+		Priority:      priority,
+		Preconditions: get("Preconditions"),
+		Steps:         zipSteps(splitLines(get("Steps")), splitLines(get("Expected Result"))),
+	}
+
+	refs := splitNonEmpty(get("References"), ",")
+	for i, ref := range refs {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			continue
+		}
+		if i == 0 {
+			tc.Requirement = &qascsv.Requirement{Title: ref}
+		} else {
+			tc.Tags = append(tc.Tags, ref)
+		}
+	}
+
+	return tc
+}
+
+func zipSteps(actions, expected []string) []qascsv.Step {
+	n := len(actions)
+	if len(expected) > n {
+		n = len(expected)
+	}
+	if n == 0 {
+		return nil
+	}
+
+	steps := make([]qascsv.Step, n)
+	for i := 0; i < n; i++ {
+		if i < len(actions) {
+			steps[i].Action = actions[i]
+		}
+		if i < len(expected) {
+			steps[i].Expected = expected[i]
+		}
+	}
+	return steps
+}
+
+func splitLines(s string) []string {
+	return splitNonEmpty(s, "\n")
+}
+
+func splitNonEmpty(s, sep string) []string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+
+	var out []string
+	for _, part := range strings.Split(s, sep) {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
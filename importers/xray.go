@@ -0,0 +1,125 @@
+package importers
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	qascsv "github.com/hypersequent/qasphere-csv"
+)
+
+// xrayPriority maps Xray/Zephyr's default priority names to QA Sphere
+// priorities. Unrecognised values fall back to medium.
+var xrayPriority = map[string]qascsv.Priority{
+	"highest": qascsv.PriorityHigh,
+	"high":    qascsv.PriorityHigh,
+	"medium":  qascsv.PriorityMedium,
+	"low":     qascsv.PriorityLow,
+	"lowest":  qascsv.PriorityLow,
+}
+
+// XrayImporter imports the CSV export produced by Xray/Zephyr, where a
+// test case with multiple steps is exported as multiple consecutive
+// rows sharing the same "Key": Key, Summary, Priority, Labels, Folder,
+// Precondition, Requirement, Action, Expected Result, Attachments.
+// Folder is already "/" separated and Labels/Attachments are comma
+// separated.
+type XrayImporter struct{}
+
+// NewXrayImporter returns an XrayImporter.
+func NewXrayImporter() *XrayImporter {
+	return &XrayImporter{}
+}
+
+func (i *XrayImporter) Import(r io.Reader) ([]qascsv.TestCase, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "read csv")
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("empty csv")
+	}
+
+	col := make(map[string]int, len(rows[0]))
+	for idx, name := range rows[0] {
+		col[name] = idx
+	}
+	get := func(row []string, name string) string {
+		idx, ok := col[name]
+		if !ok || idx >= len(row) {
+			return ""
+		}
+		return row[idx]
+	}
+
+	validate := validator.New()
+	var tcs []qascsv.TestCase
+	var verr error
+
+	var cur *qascsv.TestCase
+	var curKey string
+	flush := func(i int) {
+		if cur == nil {
+			return
+		}
+		if err := validate.Struct(*cur); err != nil {
+			verr = multierror.Append(verr, errors.Wrapf(err, "test case %d", i))
+			return
+		}
+		tcs = append(tcs, *cur)
+	}
+
+	tcIdx := 0
+	for _, row := range rows[1:] {
+		key := get(row, "Key")
+		if key == "" || key != curKey {
+			flush(tcIdx)
+			tcIdx++
+			curKey = key
+
+			tc := qascsv.TestCase{
+				Title:         get(row, "Summary"),
+				Folder:        splitNonEmpty(get(row, "Folder"), "/"),
+				Priority:      xrayPriority[strings.ToLower(strings.TrimSpace(get(row, "Priority")))],
+				Tags:          splitNonEmpty(get(row, "Labels"), ","),
+				Preconditions: get(row, "Precondition"),
+			}
+			if tc.Priority == "" {
+				tc.Priority = qascsv.PriorityMedium
+			}
+			if req := strings.TrimSpace(get(row, "Requirement")); req != "" {
+				tc.Requirement = &qascsv.Requirement{Title: req}
+			}
+			cur = &tc
+		}
+
+		for _, url := range splitNonEmpty(get(row, "Attachments"), ",") {
+			cur.Files = append(cur.Files, qascsv.File{Name: attachmentName(url), URL: url})
+		}
+
+		action, expected := get(row, "Action"), get(row, "Expected Result")
+		if action != "" || expected != "" {
+			cur.Steps = append(cur.Steps, qascsv.Step{Action: action, Expected: expected})
+		}
+	}
+	flush(tcIdx)
+
+	if verr != nil {
+		return nil, errors.Wrap(verr, "validation")
+	}
+
+	return tcs, nil
+}
+
+// attachmentName derives a file name from an attachment URL: the text
+// after its last "/", or the full URL if it has no "/" or ends with one.
+func attachmentName(url string) string {
+	if idx := strings.LastIndex(url, "/"); idx >= 0 && idx+1 < len(url) {
+		return url[idx+1:]
+	}
+	return url
+}
@@ -0,0 +1,210 @@
+package migrations
+
+import (
+	"strings"
+
+	qascsv "github.com/hypersequent/qasphere-csv"
+)
+
+func init() {
+	Register(NormalizeFolderCasing)
+	Register(DedupeTags)
+	Register(StripLegacyIDs)
+	Register(ExpandPreconditionsIntoFirstStep)
+	Register(SplitOversizedSteps)
+	Register(PromoteLinksToRequirements)
+}
+
+// NormalizeFolderCasing unifies folder segments that only differ in
+// case (e.g. "Bug Reports" and "bug reports") to whichever casing was
+// seen first, so the same folder doesn't show up twice in QA Sphere
+// under different casings.
+var NormalizeFolderCasing = Migration{
+	Name: "normalize-folder-casing",
+	Apply: func(q *qascsv.QASphereCSV) error {
+		tcs := q.TestCases()
+
+		canonical := make(map[string]string)
+		for _, tc := range tcs {
+			for _, seg := range tc.Folder {
+				key := strings.ToLower(seg)
+				if _, ok := canonical[key]; !ok {
+					canonical[key] = seg
+				}
+			}
+		}
+
+		for i, tc := range tcs {
+			folder := make([]string, len(tc.Folder))
+			for j, seg := range tc.Folder {
+				folder[j] = canonical[strings.ToLower(seg)]
+			}
+			tcs[i].Folder = folder
+		}
+
+		return q.ReplaceTestCases(tcs)
+	},
+}
+
+// DedupeTags removes duplicate tags from each test case, keeping the
+// first occurrence.
+var DedupeTags = Migration{
+	Name: "dedupe-tags",
+	Apply: func(q *qascsv.QASphereCSV) error {
+		tcs := q.TestCases()
+
+		for i, tc := range tcs {
+			if len(tc.Tags) == 0 {
+				continue
+			}
+
+			seen := make(map[string]bool, len(tc.Tags))
+			tags := make([]string, 0, len(tc.Tags))
+			for _, tag := range tc.Tags {
+				if seen[tag] {
+					continue
+				}
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+			tcs[i].Tags = tags
+		}
+
+		return q.ReplaceTestCases(tcs)
+	},
+}
+
+// StripLegacyIDs clears the LegacyID of every test case, for use once
+// an import's legacy references are no longer needed.
+var StripLegacyIDs = Migration{
+	Name: "strip-legacy-ids",
+	Apply: func(q *qascsv.QASphereCSV) error {
+		tcs := q.TestCases()
+		for i := range tcs {
+			tcs[i].LegacyID = ""
+		}
+		return q.ReplaceTestCases(tcs)
+	},
+}
+
+// ExpandPreconditionsIntoFirstStep turns a test case's free-form
+// Preconditions text into an explicit first step to verify, for tools
+// that migrated preconditions without their own first-class field.
+var ExpandPreconditionsIntoFirstStep = Migration{
+	Name: "expand-preconditions-into-first-step",
+	Apply: func(q *qascsv.QASphereCSV) error {
+		tcs := q.TestCases()
+
+		for i, tc := range tcs {
+			if tc.Preconditions == "" {
+				continue
+			}
+
+			steps := make([]qascsv.Step, 0, len(tc.Steps)+1)
+			steps = append(steps, qascsv.Step{Action: "Verify preconditions: " + tc.Preconditions})
+			steps = append(steps, tc.Steps...)
+
+			tcs[i].Steps = steps
+			tcs[i].Preconditions = ""
+		}
+
+		return q.ReplaceTestCases(tcs)
+	},
+}
+
+// maxStepLen is the longest an Action or Expected field may be before
+// SplitOversizedSteps breaks it up.
+const maxStepLen = 500
+
+// SplitOversizedSteps breaks a step whose Action or Expected exceeds
+// maxStepLen into multiple steps, splitting on word boundaries so that
+// long, auto-generated steps (e.g. from a bulk import) stay readable.
+var SplitOversizedSteps = Migration{
+	Name: "split-oversized-steps",
+	Apply: func(q *qascsv.QASphereCSV) error {
+		tcs := q.TestCases()
+
+		for i, tc := range tcs {
+			var steps []qascsv.Step
+			for _, step := range tc.Steps {
+				steps = append(steps, splitStep(step)...)
+			}
+			tcs[i].Steps = steps
+		}
+
+		return q.ReplaceTestCases(tcs)
+	},
+}
+
+func splitStep(step qascsv.Step) []qascsv.Step {
+	actions := splitChunks(step.Action, maxStepLen)
+	expecteds := splitChunks(step.Expected, maxStepLen)
+
+	n := len(actions)
+	if len(expecteds) > n {
+		n = len(expecteds)
+	}
+	if n == 0 {
+		return []qascsv.Step{step}
+	}
+
+	steps := make([]qascsv.Step, n)
+	for i := 0; i < n; i++ {
+		if i < len(actions) {
+			steps[i].Action = actions[i]
+		}
+		if i < len(expecteds) {
+			steps[i].Expected = expecteds[i]
+		}
+	}
+	return steps
+}
+
+func splitChunks(s string, limit int) []string {
+	if len(s) <= limit {
+		if s == "" {
+			return nil
+		}
+		return []string{s}
+	}
+
+	var chunks []string
+	words := strings.Fields(s)
+	var chunk strings.Builder
+	for _, word := range words {
+		if chunk.Len() > 0 && chunk.Len()+1+len(word) > limit {
+			chunks = append(chunks, chunk.String())
+			chunk.Reset()
+		}
+		if chunk.Len() > 0 {
+			chunk.WriteByte(' ')
+		}
+		chunk.WriteString(word)
+	}
+	if chunk.Len() > 0 {
+		chunks = append(chunks, chunk.String())
+	}
+	return chunks
+}
+
+// PromoteLinksToRequirements promotes the first Link of a test case
+// that has no Requirement into its Requirement, since many migrated
+// tools don't distinguish a primary requirement from other links.
+var PromoteLinksToRequirements = Migration{
+	Name: "promote-links-to-requirements",
+	Apply: func(q *qascsv.QASphereCSV) error {
+		tcs := q.TestCases()
+
+		for i, tc := range tcs {
+			if tc.Requirement != nil || len(tc.Links) == 0 {
+				continue
+			}
+
+			link := tc.Links[0]
+			tcs[i].Requirement = &qascsv.Requirement{Title: link.Title, URL: link.URL}
+			tcs[i].Links = append([]qascsv.Link(nil), tc.Links[1:]...)
+		}
+
+		return q.ReplaceTestCases(tcs)
+	},
+}
@@ -0,0 +1,152 @@
+// Package migrations provides named, ordered, idempotent transforms
+// that clean up a QASphereCSV's test cases in place, e.g. after
+// importing them from another tool.
+package migrations
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	qascsv "github.com/hypersequent/qasphere-csv"
+)
+
+// Migration is a single named transform applied to a QASphereCSV's
+// test cases.
+type Migration struct {
+	Name  string
+	Apply func(*qascsv.QASphereCSV) error
+}
+
+var registry = map[string]Migration{}
+
+// Register adds m to the set of migrations available by name via Get.
+// Built-in migrations register themselves on package init;
+// call Register from your own init to add custom ones.
+func Register(m Migration) {
+	registry[m.Name] = m
+}
+
+// Get looks up a registered migration by name.
+func Get(name string) (Migration, bool) {
+	m, ok := registry[name]
+	return m, ok
+}
+
+// Run applies each migration in order to qas, stopping at the first
+// error.
+func Run(qas *qascsv.QASphereCSV, migrations []Migration) error {
+	for _, m := range migrations {
+		if err := m.Apply(qas); err != nil {
+			return errors.Wrapf(err, "migration %q", m.Name)
+		}
+	}
+	return nil
+}
+
+// DryRunResult reports how many test cases a single migration would
+// affect.
+type DryRunResult struct {
+	Name          string
+	AffectedCount int
+}
+
+// DryRun reports, for each migration in order, how many test cases it
+// would change, without mutating qas. Migrations are chained so that
+// later entries are evaluated against the outcome of earlier ones, the
+// same way Run would apply them.
+func DryRun(qas *qascsv.QASphereCSV, migrations []Migration) ([]DryRunResult, error) {
+	results := make([]DryRunResult, 0, len(migrations))
+
+	tcs := qas.TestCases()
+	for _, m := range migrations {
+		scratch := qascsv.NewQASphereCSV()
+		if err := scratch.AddTestCases(cloneTestCases(tcs)); err != nil {
+			return nil, errors.Wrapf(err, "migration %q", m.Name)
+		}
+		if err := m.Apply(scratch); err != nil {
+			return nil, errors.Wrapf(err, "migration %q", m.Name)
+		}
+
+		after := scratch.TestCases()
+		affected, err := countAffected(tcs, after)
+		if err != nil {
+			return nil, errors.Wrapf(err, "migration %q", m.Name)
+		}
+		results = append(results, DryRunResult{
+			Name:          m.Name,
+			AffectedCount: affected,
+		})
+		tcs = after
+	}
+
+	return results, nil
+}
+
+// countAffected counts how many test cases changed between before and
+// after. QASphereCSV.TestCases() re-sorts by folder on every call, so a
+// migration that touches the folder (e.g. NormalizeFolderCasing) can
+// reorder the slice without actually changing every test case in it;
+// comparing position by position would then misreport untouched test
+// cases as affected. Instead, before and after are each reduced to a
+// multiset keyed by their full content, and affected counts the before
+// entries whose content has no matching counterpart left in after.
+func countAffected(before, after []TestCase) (int, error) {
+	beforeCounts, err := testCaseCounts(before)
+	if err != nil {
+		return 0, err
+	}
+	afterCounts, err := testCaseCounts(after)
+	if err != nil {
+		return 0, err
+	}
+
+	affected := 0
+	for key, count := range beforeCounts {
+		if remaining := count - afterCounts[key]; remaining > 0 {
+			affected += remaining
+		}
+	}
+	return affected, nil
+}
+
+// testCaseCounts returns how many times each distinct test case (by
+// full content) appears in tcs.
+func testCaseCounts(tcs []TestCase) (map[string]int, error) {
+	counts := make(map[string]int, len(tcs))
+	for _, tc := range tcs {
+		key, err := json.Marshal(tc)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal test case")
+		}
+		counts[string(key)]++
+	}
+	return counts, nil
+}
+
+func cloneTestCases(tcs []TestCase) []TestCase {
+	clone := make([]TestCase, len(tcs))
+	for i, tc := range tcs {
+		clone[i] = tc
+		clone[i].Folder = append([]string(nil), tc.Folder...)
+		clone[i].Tags = append([]string(nil), tc.Tags...)
+		clone[i].Steps = append([]Step(nil), tc.Steps...)
+		clone[i].Files = append([]File(nil), tc.Files...)
+		clone[i].Links = append([]Link(nil), tc.Links...)
+		if tc.Requirement != nil {
+			req := *tc.Requirement
+			clone[i].Requirement = &req
+		}
+	}
+	return clone
+}
+
+type (
+	// TestCase, Step, File and Link are aliased from qascsv so the
+	// DryRun helpers in this file can refer to them without a
+	// package-qualified name.
+	TestCase = qascsv.TestCase
+	Step     = qascsv.Step
+	File     = qascsv.File
+	Link     = qascsv.Link
+)
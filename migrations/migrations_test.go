@@ -0,0 +1,141 @@
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	qascsv "github.com/hypersequent/qasphere-csv"
+)
+
+func newTestCSV(t *testing.T, tcs []qascsv.TestCase) *qascsv.QASphereCSV {
+	t.Helper()
+	q := qascsv.NewQASphereCSV()
+	require.NoError(t, q.AddTestCases(tcs))
+	return q
+}
+
+func TestNormalizeFolderCasing(t *testing.T) {
+	q := newTestCSV(t, []qascsv.TestCase{
+		{Title: "t1", Folder: []string{"Bug Reports"}, Priority: qascsv.PriorityHigh},
+		{Title: "t2", Folder: []string{"bug reports"}, Priority: qascsv.PriorityHigh},
+	})
+
+	require.NoError(t, Run(q, []Migration{NormalizeFolderCasing}))
+
+	for _, tc := range q.TestCases() {
+		require.Equal(t, []string{"Bug Reports"}, tc.Folder)
+	}
+}
+
+func TestDedupeTags(t *testing.T) {
+	q := newTestCSV(t, []qascsv.TestCase{
+		{Title: "t1", Folder: []string{"root"}, Priority: qascsv.PriorityHigh, Tags: []string{"a", "b", "a"}},
+	})
+
+	require.NoError(t, Run(q, []Migration{DedupeTags}))
+	require.Equal(t, []string{"a", "b"}, q.TestCases()[0].Tags)
+}
+
+func TestStripLegacyIDs(t *testing.T) {
+	q := newTestCSV(t, []qascsv.TestCase{
+		{Title: "t1", Folder: []string{"root"}, Priority: qascsv.PriorityHigh, LegacyID: "old-1"},
+	})
+
+	require.NoError(t, Run(q, []Migration{StripLegacyIDs}))
+	require.Equal(t, "", q.TestCases()[0].LegacyID)
+}
+
+func TestExpandPreconditionsIntoFirstStep(t *testing.T) {
+	q := newTestCSV(t, []qascsv.TestCase{
+		{
+			Title: "t1", Folder: []string{"root"}, Priority: qascsv.PriorityHigh,
+			Preconditions: "User is logged in",
+			Steps:         []qascsv.Step{{Action: "Click button"}},
+		},
+	})
+
+	require.NoError(t, Run(q, []Migration{ExpandPreconditionsIntoFirstStep}))
+
+	tc := q.TestCases()[0]
+	require.Equal(t, "", tc.Preconditions)
+	require.Len(t, tc.Steps, 2)
+	require.Equal(t, "Verify preconditions: User is logged in", tc.Steps[0].Action)
+	require.Equal(t, "Click button", tc.Steps[1].Action)
+}
+
+func TestSplitOversizedSteps(t *testing.T) {
+	long := ""
+	for i := 0; i < 200; i++ {
+		long += "word "
+	}
+
+	q := newTestCSV(t, []qascsv.TestCase{
+		{Title: "t1", Folder: []string{"root"}, Priority: qascsv.PriorityHigh, Steps: []qascsv.Step{{Action: long}}},
+	})
+
+	require.NoError(t, Run(q, []Migration{SplitOversizedSteps}))
+
+	tc := q.TestCases()[0]
+	require.Greater(t, len(tc.Steps), 1)
+	for _, step := range tc.Steps {
+		require.LessOrEqual(t, len(step.Action), maxStepLen)
+	}
+}
+
+func TestPromoteLinksToRequirements(t *testing.T) {
+	q := newTestCSV(t, []qascsv.TestCase{
+		{
+			Title: "t1", Folder: []string{"root"}, Priority: qascsv.PriorityHigh,
+			Links: []qascsv.Link{{Title: "spec", URL: "http://spec"}, {Title: "other", URL: "http://other"}},
+		},
+	})
+
+	require.NoError(t, Run(q, []Migration{PromoteLinksToRequirements}))
+
+	tc := q.TestCases()[0]
+	require.NotNil(t, tc.Requirement)
+	require.Equal(t, "spec", tc.Requirement.Title)
+	require.Len(t, tc.Links, 1)
+	require.Equal(t, "other", tc.Links[0].Title)
+}
+
+func TestDryRun(t *testing.T) {
+	q := newTestCSV(t, []qascsv.TestCase{
+		{Title: "t1", Folder: []string{"root"}, Priority: qascsv.PriorityHigh, Tags: []string{"a", "a"}},
+		{Title: "t2", Folder: []string{"root"}, Priority: qascsv.PriorityHigh, Tags: []string{"a"}},
+	})
+
+	results, err := DryRun(q, []Migration{DedupeTags})
+	require.NoError(t, err)
+	require.Equal(t, []DryRunResult{{Name: "dedupe-tags", AffectedCount: 1}}, results)
+
+	// DryRun must not mutate the original.
+	require.Equal(t, []string{"a", "a"}, q.TestCases()[0].Tags)
+}
+
+// TestDryRunDoesNotMistakeResortForChange reproduces a case where
+// NormalizeFolderCasing changes folder casing for one test case, which
+// shifts QASphereCSV.TestCases()'s alphabetical folder sort enough to
+// move it past an untouched test case. Naive positional comparison
+// between before/after would misreport that untouched case as affected.
+func TestDryRunDoesNotMistakeResortForChange(t *testing.T) {
+	q := newTestCSV(t, []qascsv.TestCase{
+		{Title: "t-zebra-upper", Folder: []string{"Zebra"}, Priority: qascsv.PriorityHigh},
+		{Title: "t-apple", Folder: []string{"apple"}, Priority: qascsv.PriorityHigh},
+		{Title: "t-zebra-lower", Folder: []string{"zebra"}, Priority: qascsv.PriorityHigh},
+	})
+
+	results, err := DryRun(q, []Migration{NormalizeFolderCasing})
+	require.NoError(t, err)
+	require.Equal(t, []DryRunResult{{Name: "normalize-folder-casing", AffectedCount: 1}}, results)
+}
+
+func TestGet(t *testing.T) {
+	m, ok := Get("dedupe-tags")
+	require.True(t, ok)
+	require.Equal(t, "dedupe-tags", m.Name)
+
+	_, ok = Get("does-not-exist")
+	require.False(t, ok)
+}
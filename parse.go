@@ -0,0 +1,240 @@
+package qascsv
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+// linkPattern matches a single `[title](url)` markdown entry, greedily
+// capturing the title so that titles containing "]" or "}" are still
+// recovered correctly.
+var linkPattern = regexp.MustCompile(`^\[(.*)\]\((.*)\)$`)
+
+// stepHeaderPattern matches a "Step N" or "Expected N" header and
+// captures the step index.
+var stepHeaderPattern = regexp.MustCompile(`^(Step|Expected) (\d+)$`)
+
+// ParseCSV parses a CSV produced by GenerateCSV/WriteCSVToFile back into
+// TestCase structs. The header is used to locate the static columns and
+// the variable "Step N"/"Expected N" columns, so reordered columns and
+// an arbitrary number of steps are both supported. Every parsed test
+// case is run through the same validator used by AddTestCase.
+func ParseCSV(r io.Reader) ([]TestCase, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "read csv")
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("empty csv")
+	}
+
+	cols, err := parseHeader(rows[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "parse header")
+	}
+
+	validate := validator.New()
+
+	tcs := make([]TestCase, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		tc, err := cols.parseRow(row)
+		if err != nil {
+			return nil, errors.Wrapf(err, "row %d", i)
+		}
+		if err := validate.Struct(tc); err != nil {
+			return nil, errors.Wrapf(err, "row %d validation", i)
+		}
+		tcs = append(tcs, tc)
+	}
+
+	return tcs, nil
+}
+
+// ReadCSVFromFile reads and parses the CSV file at the given path. See
+// ParseCSV for details on the expected format.
+func ReadCSVFromFile(file string) ([]TestCase, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "open csv")
+	}
+	defer f.Close()
+
+	tcs, err := ParseCSV(f)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse csv")
+	}
+
+	return tcs, nil
+}
+
+// columns records, for a parsed header row, the column index of each
+// static field and the step pairs discovered.
+type columns struct {
+	static map[string]int
+	steps  []stepCols
+}
+
+type stepCols struct {
+	action   int
+	expected int
+}
+
+func parseHeader(header []string) (columns, error) {
+	cols := columns{static: make(map[string]int)}
+	stepCol := make(map[int]int)
+	expCol := make(map[int]int)
+
+	for i, name := range header {
+		if slicesContains(staticColumns, name) {
+			cols.static[name] = i
+			continue
+		}
+
+		m := stepHeaderPattern.FindStringSubmatch(name)
+		if m == nil {
+			continue
+		}
+		n, err := strconv.Atoi(m[2])
+		if err != nil {
+			continue
+		}
+		if m[1] == "Step" {
+			stepCol[n] = i
+		} else {
+			expCol[n] = i
+		}
+	}
+
+	for _, name := range staticColumns {
+		if _, ok := cols.static[name]; !ok {
+			return columns{}, errors.Errorf("missing column %q", name)
+		}
+	}
+
+	maxStep := 0
+	for n := range stepCol {
+		if n > maxStep {
+			maxStep = n
+		}
+	}
+	for n := range expCol {
+		if n > maxStep {
+			maxStep = n
+		}
+	}
+
+	cols.steps = make([]stepCols, maxStep)
+	for n := 1; n <= maxStep; n++ {
+		action, hasAction := stepCol[n]
+		expected, hasExpected := expCol[n]
+		if !hasAction || !hasExpected {
+			return columns{}, errors.Errorf("incomplete step columns for step %d", n)
+		}
+		cols.steps[n-1] = stepCols{action: action, expected: expected}
+	}
+
+	return cols, nil
+}
+
+func (c columns) parseRow(row []string) (TestCase, error) {
+	get := func(name string) string {
+		return row[c.static[name]]
+	}
+
+	draft, err := strconv.ParseBool(get("Draft"))
+	if err != nil {
+		return TestCase{}, errors.Wrap(err, "parse draft")
+	}
+
+	tc := TestCase{
+		Title:         get("Name"),
+		LegacyID:      get("Legacy ID"),
+		Folder:        splitNonEmpty(get("Folder"), "/"),
+		Priority:      Priority(get("Priority")),
+		Tags:          splitNonEmpty(get("Tags"), ","),
+		Preconditions: get("Preconditions"),
+		Draft:         draft,
+	}
+
+	if requirement := get("Requirements"); requirement != "" {
+		m := linkPattern.FindStringSubmatch(requirement)
+		if m == nil {
+			return TestCase{}, errors.Errorf("invalid requirement %q", requirement)
+		}
+		tc.Requirement = &Requirement{Title: m[1], URL: m[2]}
+	}
+
+	for _, entry := range splitLinkEntries(get("Links")) {
+		m := linkPattern.FindStringSubmatch(entry)
+		if m == nil {
+			return TestCase{}, errors.Errorf("invalid link %q", entry)
+		}
+		tc.Links = append(tc.Links, Link{Title: m[1], URL: m[2]})
+	}
+
+	if files := get("Files"); files != "" {
+		if err := json.Unmarshal([]byte(files), &tc.Files); err != nil {
+			return TestCase{}, errors.Wrap(err, "unmarshal files")
+		}
+	}
+
+	for _, sc := range c.steps {
+		tc.Steps = append(tc.Steps, Step{Action: row[sc.action], Expected: row[sc.expected]})
+	}
+	tc.Steps = trimTrailingEmptySteps(tc.Steps)
+
+	return tc, nil
+}
+
+func trimTrailingEmptySteps(steps []Step) []Step {
+	n := len(steps)
+	for n > 0 && steps[n-1].Action == "" && steps[n-1].Expected == "" {
+		n--
+	}
+	return steps[:n]
+}
+
+// splitLinkEntries splits a comma-joined list of `[title](url)` entries
+// back into its individual entries. It splits on the "),[" boundary
+// between entries rather than on every comma, so commas embedded in a
+// title or URL do not break the split.
+func splitLinkEntries(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	parts := strings.Split(s, "),[")
+	for i := range parts {
+		if i > 0 {
+			parts[i] = "[" + parts[i]
+		}
+		if i < len(parts)-1 {
+			parts[i] = parts[i] + ")"
+		}
+	}
+	return parts
+}
+
+func splitNonEmpty(s, sep string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, sep)
+}
+
+func slicesContains(s []string, v string) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -262,6 +262,22 @@ func TestWriteCSVMultipleTCasesSuccess(t *testing.T) {
 	require.Equal(t, strings.ReplaceAll(string(b), "[BACKTICK]", "`"), string(b))
 }
 
+func TestGenerateCSVRejectsDataOnlyFile(t *testing.T) {
+	qasCSV := NewQASphereCSV()
+	err := qasCSV.AddTestCase(TestCase{
+		Title:    "tc-with-local-file",
+		Folder:   []string{"root"},
+		Priority: "high",
+		Files: []File{
+			{Name: "file-1.csv", MimeType: "text/csv", Size: 10, Data: []byte("a,b,c")},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = qasCSV.GenerateCSV()
+	require.Error(t, err)
+}
+
 func TestFailureTestCases(t *testing.T) {
 	for _, tc := range failureTestCases {
 		t.Run(tc.Title, func(t *testing.T) {
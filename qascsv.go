@@ -50,12 +50,18 @@ type File struct {
 	// The name of the file. (required)
 	Name string `validate:"required" json:"file_name"`
 	// If the file is already uploaded on QA Sphere, then its ID. (optional)
-	ID string `validate:"required_without=URL" json:"id,omitempty"`
-	// The URL of the file. If the file is not uploaded on QA Sphere,
-	// the URL is required. (optional)
-	URL      string `validate:"required_without=ID,omitempty,http_url" json:"url,omitempty"`
+	ID string `validate:"required_without_all=URL Data" json:"id,omitempty"`
+	// The URL of the file. If the file is not uploaded on QA Sphere and
+	// its raw content isn't available locally either, the URL is
+	// required. (optional)
+	URL      string `validate:"required_without_all=ID Data,omitempty,http_url" json:"url,omitempty"`
 	MimeType string `json:"mime_type"`
 	Size     int64  `json:"size"`
+	// The raw content of the file, for a file that hasn't been uploaded
+	// anywhere and has no retrievable URL. Not included in the CSV;
+	// consumers that can accept raw bytes (e.g. qascsv/api) upload it
+	// directly instead. (optional)
+	Data []byte `validate:"required_without_all=ID URL" json:"-"`
 }
 
 // Step represents a single action to perform in a test case.
@@ -145,6 +151,27 @@ func (q *QASphereCSV) AddTestCases(tcs []TestCase) error {
 	return nil
 }
 
+// TestCases returns every test case added so far, in the same folder
+// order GenerateCSV would emit them in.
+func (q *QASphereCSV) TestCases() []TestCase {
+	tcs := make([]TestCase, 0, q.numTCases)
+	for _, f := range q.getFolders() {
+		tcs = append(tcs, q.folderTCaseMap[f]...)
+	}
+	return tcs
+}
+
+// ReplaceTestCases discards every test case added so far and re-adds
+// tcs in its place, as if NewQASphereCSV().AddTestCases(tcs) had been
+// called. This is intended for pipelines that need to transform the
+// whole set of test cases in place, such as the migrations package.
+func (q *QASphereCSV) ReplaceTestCases(tcs []TestCase) error {
+	q.folderTCaseMap = make(map[string][]TestCase)
+	q.numTCases = 0
+	q.maxSteps = 0
+	return q.AddTestCases(tcs)
+}
+
 func (q *QASphereCSV) GenerateCSV() (string, error) {
 	w := &strings.Builder{}
 	if err := q.writeCSV(w); err != nil {
@@ -192,54 +219,77 @@ func (q *QASphereCSV) getFolders() []string {
 
 func (q *QASphereCSV) getCSVRows() ([][]string, error) {
 	rows := make([][]string, 0, q.numTCases+1)
-	numCols := len(staticColumns) + 2*q.maxSteps
-
-	rows = append(rows, append(make([]string, 0, numCols), staticColumns...))
-	for i := 0; i < q.maxSteps; i++ {
-		rows[0] = append(rows[0], fmt.Sprintf("Step %d", i+1), fmt.Sprintf("Expected %d", i+1))
-	}
+	rows = append(rows, csvHeader(q.maxSteps))
 
 	folders := q.getFolders()
 	for _, f := range folders {
 		for _, tc := range q.folderTCaseMap[f] {
-			var requirement string
-			if tc.Requirement != nil {
-				requirement = fmt.Sprintf("[%s](%s)", tc.Requirement.Title, tc.Requirement.URL)
+			row, err := csvRow(f, tc, q.maxSteps)
+			if err != nil {
+				return nil, err
 			}
+			rows = append(rows, row)
+		}
+	}
 
-			var links []string
-			for _, link := range tc.Links {
-				links = append(links, fmt.Sprintf("[%s](%s)", link.Title, link.URL))
-			}
+	return rows, nil
+}
 
-			var files string
-			if len(tc.Files) > 0 {
-				filesb, err := json.Marshal(tc.Files)
-				if err != nil {
-					return nil, errors.Wrap(err, "json marshal files")
-				}
-				files = string(filesb)
-			}
+// csvHeader builds the header row for a CSV with the given number of
+// step columns.
+func csvHeader(maxSteps int) []string {
+	numCols := len(staticColumns) + 2*maxSteps
+	header := append(make([]string, 0, numCols), staticColumns...)
+	for i := 0; i < maxSteps; i++ {
+		header = append(header, fmt.Sprintf("Step %d", i+1), fmt.Sprintf("Expected %d", i+1))
+	}
+	return header
+}
+
+// csvRow builds a single data row for tc filed under folder, padding or
+// truncating its steps to maxSteps columns.
+func csvRow(folder string, tc TestCase, maxSteps int) ([]string, error) {
+	var requirement string
+	if tc.Requirement != nil {
+		requirement = fmt.Sprintf("[%s](%s)", tc.Requirement.Title, tc.Requirement.URL)
+	}
+
+	var links []string
+	for _, link := range tc.Links {
+		links = append(links, fmt.Sprintf("[%s](%s)", link.Title, link.URL))
+	}
 
-			row := make([]string, 0, numCols)
-			row = append(row, f, tc.Title, tc.LegacyID, strconv.FormatBool(tc.Draft),
-				string(tc.Priority), strings.Join(tc.Tags, ","), requirement,
-				strings.Join(links, ","), files, tc.Preconditions)
-
-			numSteps := len(tc.Steps)
-			for i := 0; i < q.maxSteps; i++ {
-				if i < numSteps {
-					row = append(row, tc.Steps[i].Action, tc.Steps[i].Expected)
-				} else {
-					row = append(row, "", "")
-				}
+	var files string
+	if len(tc.Files) > 0 {
+		for _, f := range tc.Files {
+			if f.ID == "" && f.URL == "" {
+				return nil, errors.Errorf("file %q has no ID or URL: data-only files can't be represented in a CSV row", f.Name)
 			}
+		}
 
-			rows = append(rows, row)
+		filesb, err := json.Marshal(tc.Files)
+		if err != nil {
+			return nil, errors.Wrap(err, "json marshal files")
 		}
+		files = string(filesb)
 	}
 
-	return rows, nil
+	numCols := len(staticColumns) + 2*maxSteps
+	row := make([]string, 0, numCols)
+	row = append(row, folder, tc.Title, tc.LegacyID, strconv.FormatBool(tc.Draft),
+		string(tc.Priority), strings.Join(tc.Tags, ","), requirement,
+		strings.Join(links, ","), files, tc.Preconditions)
+
+	numSteps := len(tc.Steps)
+	for i := 0; i < maxSteps; i++ {
+		if i < numSteps {
+			row = append(row, tc.Steps[i].Action, tc.Steps[i].Expected)
+		} else {
+			row = append(row, "", "")
+		}
+	}
+
+	return row, nil
 }
 
 func (q *QASphereCSV) writeCSV(w io.Writer) error {
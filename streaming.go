@@ -0,0 +1,180 @@
+package qascsv
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+// Writer streams a QA Sphere CSV to an io.Writer one test case at a
+// time, instead of buffering every test case in memory like
+// QASphereCSV does. Because the header needs the step column count
+// upfront, the number of steps must be fixed before the first test
+// case is written, either via WithMaxSteps or by using WriteStagedCSV.
+type Writer struct {
+	csvw     *csv.Writer
+	validate *validator.Validate
+
+	maxSteps      int
+	headerWritten bool
+}
+
+// WriterOption configures a Writer returned by NewStreamingWriter.
+type WriterOption func(*Writer)
+
+// WithMaxSteps fixes the number of step columns in the CSV upfront.
+// Test cases with more steps than n are truncated to n steps; test
+// cases with fewer are padded with empty columns.
+func WithMaxSteps(n int) WriterOption {
+	return func(w *Writer) {
+		w.maxSteps = n
+	}
+}
+
+// NewStreamingWriter returns a Writer that writes directly to w. The
+// header is written lazily on the first WriteTestCase call, using the
+// step count fixed by WithMaxSteps (zero if not given).
+func NewStreamingWriter(w io.Writer, opts ...WriterOption) (*Writer, error) {
+	sw := &Writer{
+		csvw:     csv.NewWriter(w),
+		validate: validator.New(),
+	}
+	for _, opt := range opts {
+		opt(sw)
+	}
+	return sw, nil
+}
+
+// WriteTestCase validates tc and writes it as the next CSV row, writing
+// the header first if this is the first row.
+func (w *Writer) WriteTestCase(tc TestCase) error {
+	if err := w.validate.Struct(tc); err != nil {
+		return errors.Wrap(err, "test case validation")
+	}
+
+	if !w.headerWritten {
+		if err := w.csvw.Write(csvHeader(w.maxSteps)); err != nil {
+			return errors.Wrap(err, "write header")
+		}
+		w.headerWritten = true
+	}
+
+	row, err := csvRow(strings.Join(tc.Folder, "/"), tc, w.maxSteps)
+	if err != nil {
+		return errors.Wrap(err, "build row")
+	}
+
+	if err := w.csvw.Write(row); err != nil {
+		return errors.Wrap(err, "write row")
+	}
+
+	return nil
+}
+
+// AddTestCasesFrom drains iter, writing each test case it yields until
+// iter reports no more are available (ok == false) or returns an error.
+func (w *Writer) AddTestCasesFrom(iter func() (TestCase, bool, error)) error {
+	for {
+		tc, ok, err := iter()
+		if err != nil {
+			return errors.Wrap(err, "iterate test cases")
+		}
+		if !ok {
+			return nil
+		}
+		if err := w.WriteTestCase(tc); err != nil {
+			return err
+		}
+	}
+}
+
+// Flush flushes any buffered CSV data to the underlying writer.
+func (w *Writer) Flush() error {
+	w.csvw.Flush()
+	return errors.Wrap(w.csvw.Error(), "flush csv")
+}
+
+// Close writes the header if no test case was ever written, then
+// flushes the writer. It does not close the underlying io.Writer.
+func (w *Writer) Close() error {
+	if !w.headerWritten {
+		if err := w.csvw.Write(csvHeader(w.maxSteps)); err != nil {
+			return errors.Wrap(err, "write header")
+		}
+		w.headerWritten = true
+	}
+	return w.Flush()
+}
+
+// Stager stages test cases as newline-delimited JSON onto an
+// io.Writer, typically a temporary file, for later two-pass streaming
+// via WriteStagedCSV. This lets the number of step columns be inferred
+// without holding every test case in memory at once.
+type Stager struct {
+	enc *json.Encoder
+}
+
+// NewStager returns a Stager that writes staged test cases to w.
+func NewStager(w io.Writer) *Stager {
+	return &Stager{enc: json.NewEncoder(w)}
+}
+
+// Stage appends tc to the staging stream.
+func (s *Stager) Stage(tc TestCase) error {
+	return errors.Wrap(s.enc.Encode(tc), "stage test case")
+}
+
+// WriteStagedCSV performs a two-pass write of the test cases staged via
+// a Stager into r: it first scans r to compute the max number of
+// steps, seeks r back to the start, then streams the final CSV to w.
+func WriteStagedCSV(w io.Writer, r io.ReadSeeker) error {
+	maxSteps, err := scanMaxSteps(r)
+	if err != nil {
+		return errors.Wrap(err, "scan staged test cases")
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return errors.Wrap(err, "seek staging file")
+	}
+
+	sw, err := NewStreamingWriter(w, WithMaxSteps(maxSteps))
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(r)
+	for {
+		var tc TestCase
+		if err := dec.Decode(&tc); err == io.EOF {
+			break
+		} else if err != nil {
+			return errors.Wrap(err, "replay staged test cases")
+		}
+		if err := sw.WriteTestCase(tc); err != nil {
+			return err
+		}
+	}
+
+	return sw.Close()
+}
+
+func scanMaxSteps(r io.Reader) (int, error) {
+	maxSteps := 0
+	dec := json.NewDecoder(r)
+	for {
+		var tc TestCase
+		if err := dec.Decode(&tc); err == io.EOF {
+			break
+		} else if err != nil {
+			return 0, err
+		}
+		if len(tc.Steps) > maxSteps {
+			maxSteps = len(tc.Steps)
+		}
+	}
+	return maxSteps, nil
+}
@@ -0,0 +1,141 @@
+package qascsv
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamingWriterWritesRowsInArrivalOrder(t *testing.T) {
+	sb := &strings.Builder{}
+	sw, err := NewStreamingWriter(sb, WithMaxSteps(2))
+	require.NoError(t, err)
+
+	for _, tc := range successTestCases {
+		require.NoError(t, sw.WriteTestCase(tc))
+	}
+	require.NoError(t, sw.Close())
+
+	// Unlike QASphereCSV, the streaming writer never buffers, so rows
+	// come out in arrival order rather than sorted by folder.
+	require.Equal(t, expectedStreamingCSV(t, successTestCases, 2), sb.String())
+}
+
+func expectedStreamingCSV(t *testing.T, tcs []TestCase, maxSteps int) string {
+	t.Helper()
+	w := &strings.Builder{}
+	csvw := csv.NewWriter(w)
+	require.NoError(t, csvw.Write(csvHeader(maxSteps)))
+	for _, tc := range tcs {
+		row, err := csvRow(strings.Join(tc.Folder, "/"), tc, maxSteps)
+		require.NoError(t, err)
+		require.NoError(t, csvw.Write(row))
+	}
+	csvw.Flush()
+	require.NoError(t, csvw.Error())
+	return w.String()
+}
+
+func TestStreamingWriterTruncatesExtraSteps(t *testing.T) {
+	sb := &strings.Builder{}
+	sw, err := NewStreamingWriter(sb, WithMaxSteps(1))
+	require.NoError(t, err)
+
+	require.NoError(t, sw.WriteTestCase(TestCase{
+		Title:    "tc",
+		Folder:   []string{"root"},
+		Priority: PriorityHigh,
+		Steps: []Step{
+			{Action: "action-1", Expected: "expected-1"},
+			{Action: "action-2", Expected: "expected-2"},
+		},
+	}))
+	require.NoError(t, sw.Close())
+
+	require.Equal(t, "Folder,Name,Legacy ID,Draft,Priority,Tags,Requirements,Links,Files,Preconditions,Step 1,Expected 1\n"+
+		"root,tc,,false,high,,,,,,action-1,expected-1\n", sb.String())
+}
+
+func TestStreamingWriterValidationError(t *testing.T) {
+	sb := &strings.Builder{}
+	sw, err := NewStreamingWriter(sb)
+	require.NoError(t, err)
+
+	err = sw.WriteTestCase(TestCase{Title: "", Folder: []string{"root"}, Priority: PriorityHigh})
+	require.Error(t, err)
+}
+
+func TestAddTestCasesFrom(t *testing.T) {
+	sb := &strings.Builder{}
+	sw, err := NewStreamingWriter(sb, WithMaxSteps(0))
+	require.NoError(t, err)
+
+	tcs := []TestCase{
+		{Title: "tc-1", Folder: []string{"root"}, Priority: PriorityHigh},
+		{Title: "tc-2", Folder: []string{"root"}, Priority: PriorityLow},
+	}
+	i := 0
+	iter := func() (TestCase, bool, error) {
+		if i >= len(tcs) {
+			return TestCase{}, false, nil
+		}
+		tc := tcs[i]
+		i++
+		return tc, true, nil
+	}
+
+	require.NoError(t, sw.AddTestCasesFrom(iter))
+	require.NoError(t, sw.Close())
+	require.Equal(t, "Folder,Name,Legacy ID,Draft,Priority,Tags,Requirements,Links,Files,Preconditions\n"+
+		"root,tc-1,,false,high,,,,,\n"+
+		"root,tc-2,,false,low,,,,,\n", sb.String())
+}
+
+func TestWriteStagedCSVInfersMaxSteps(t *testing.T) {
+	staging := &stagingBuffer{}
+	stager := NewStager(staging)
+	for _, tc := range successTestCases {
+		require.NoError(t, stager.Stage(tc))
+	}
+
+	out := &strings.Builder{}
+	require.NoError(t, WriteStagedCSV(out, staging))
+
+	require.Equal(t, expectedStreamingCSV(t, successTestCases, 2), out.String())
+}
+
+// stagingBuffer is an in-memory io.ReadWriteSeeker standing in for a
+// staging file on disk.
+type stagingBuffer struct {
+	buf    []byte
+	offset int64
+}
+
+func (s *stagingBuffer) Write(p []byte) (int, error) {
+	s.buf = append(s.buf, p...)
+	return len(p), nil
+}
+
+func (s *stagingBuffer) Read(p []byte) (int, error) {
+	if s.offset >= int64(len(s.buf)) {
+		return 0, io.EOF
+	}
+	n := copy(p, s.buf[s.offset:])
+	s.offset += int64(n)
+	return n, nil
+}
+
+func (s *stagingBuffer) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case 0:
+		s.offset = offset
+	case 1:
+		s.offset += offset
+	case 2:
+		s.offset = int64(len(s.buf)) + offset
+	}
+	return s.offset, nil
+}